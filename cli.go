@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// newRootCmd builds the cobra command tree. With no subcommand it falls
+// through to the existing interactive TUI, so "server-cli" on its own
+// behaves exactly as it always has.
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "server-cli",
+		Short: "Run diagnostic and maintenance scripts against configured servers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTUI()
+		},
+	}
+
+	root.AddCommand(newListCmd())
+	root.AddCommand(newRunCmd())
+	root.AddCommand(newValidateCmd())
+
+	return root
+}
+
+func loadConfigForCLI() (Config, error) {
+	configPath := os.Getenv("SERVER_CLI_CONFIG")
+	if configPath == "" {
+		configPath = getDefaultConfigPath()
+	}
+	return LoadConfig(configPath)
+}
+
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [category]",
+		Short: "List categories, or the servers within one",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := loadConfigForCLI()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				var names []string
+				for name := range config.Categories {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+
+				for _, name := range names {
+					if desc := config.Categories[name].Description; desc != "" {
+						fmt.Printf("%s - %s\n", name, desc)
+					} else {
+						fmt.Println(name)
+					}
+				}
+				return nil
+			}
+
+			category, ok := config.Categories[args[0]]
+			if !ok {
+				return fmt.Errorf("category %q not found", args[0])
+			}
+
+			var names []string
+			for name := range category.Servers {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				if desc := category.Servers[name].Description; desc != "" {
+					fmt.Printf("%s - %s\n", name, desc)
+				} else {
+					fmt.Println(name)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// runResult is the shape emitted by "run --output json".
+type runResult struct {
+	Server     string `json:"server"`
+	Category   string `json:"category"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMs int64  `json:"duration_ms"`
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+}
+
+func newRunCmd() *cobra.Command {
+	var envOverrides []string
+	var output string
+	var tokenStdin bool
+
+	cmd := &cobra.Command{
+		Use:   "run <category> <server>",
+		Short: "Run a server's script directly, without the TUI",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			category, serverName := args[0], args[1]
+
+			config, err := loadConfigForCLI()
+			if err != nil {
+				return err
+			}
+
+			categoryConfig, ok := config.Categories[category]
+			if !ok {
+				return fmt.Errorf("category %q not found", category)
+			}
+			serverConfig, ok := categoryConfig.Servers[serverName]
+			if !ok {
+				return fmt.Errorf("server %q not found in category %q", serverName, category)
+			}
+
+			for _, kv := range envOverrides {
+				key, value, ok := strings.Cut(kv, "=")
+				if !ok {
+					return fmt.Errorf("invalid --env value %q, expected KEY=VALUE", kv)
+				}
+				if serverConfig.EnvVars == nil {
+					serverConfig.EnvVars = map[string]string{}
+				}
+				serverConfig.EnvVars[key] = value
+			}
+
+			vaultToken, err := resolveVaultToken(tokenStdin)
+			if err != nil {
+				return err
+			}
+
+			execCmd, err := buildScriptCmd(serverName, categoryConfig.VaultAddr, serverConfig, vaultToken)
+			if err != nil {
+				return err
+			}
+
+			start := time.Now()
+
+			if output == "json" {
+				var stdout, stderr strings.Builder
+				execCmd.Stdout = &stdout
+				execCmd.Stderr = &stderr
+
+				runErr := execCmd.Run()
+				result := runResult{
+					Server:     serverName,
+					Category:   category,
+					ExitCode:   exitCodeOf(runErr),
+					DurationMs: time.Since(start).Milliseconds(),
+					Stdout:     stdout.String(),
+					Stderr:     stderr.String(),
+				}
+
+				encoded, err := json.Marshal(result)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(encoded))
+				return nil
+			}
+
+			execCmd.Stdout = os.Stdout
+			execCmd.Stderr = os.Stderr
+			return execCmd.Run()
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&envOverrides, "env", nil, "additional KEY=VALUE environment variables (repeatable)")
+	cmd.Flags().StringVar(&output, "output", "text", "output format: text or json")
+	cmd.Flags().BoolVar(&tokenStdin, "token-stdin", false, "read VAULT_TOKEN from stdin instead of the environment")
+
+	return cmd
+}
+
+func newValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate <config-path>",
+		Short: "Validate that a config file parses cleanly",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := LoadConfig(args[0])
+			if err != nil {
+				return err
+			}
+			if len(config.Categories) == 0 {
+				return fmt.Errorf("no categories defined in %s", args[0])
+			}
+			fmt.Printf("%s is valid: %d categor(y/ies)\n", args[0], len(config.Categories))
+			return nil
+		},
+	}
+}
+
+// resolveVaultToken reads VAULT_TOKEN from stdin (first line) when
+// tokenStdin is set, so callers can pipe it in without it showing up in
+// process listings; otherwise it falls back to the environment.
+func resolveVaultToken(tokenStdin bool) (string, error) {
+	if !tokenStdin {
+		return os.Getenv("VAULT_TOKEN"), nil
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("could not read VAULT_TOKEN from stdin: %v", err)
+		}
+		return "", fmt.Errorf("no VAULT_TOKEN provided on stdin")
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
+
+func exitCodeOf(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+	"github.com/getsops/sops/v3/decrypt"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// configReloadedMsg carries a freshly loaded, validated config after the
+// watched file changes on disk.
+type configReloadedMsg struct{ config Config }
+
+// configSchemaJSON is the JSON Schema every config file must satisfy.
+const configSchemaJSON = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type": "object",
+	"required": ["categories"],
+	"additionalProperties": false,
+	"properties": {
+		"categories": {
+			"type": "object",
+			"additionalProperties": {
+				"type": "object",
+				"required": ["servers"],
+				"additionalProperties": false,
+				"properties": {
+					"description": {"type": "string"},
+					"vault_addr": {"type": "string"},
+					"parallelism": {"type": "integer", "minimum": 0},
+					"servers": {
+						"type": "object",
+						"additionalProperties": {
+							"type": "object",
+							"additionalProperties": false,
+							"properties": {
+								"script": {"type": "string"},
+								"script_path": {"type": "string"},
+								"script_args": {"type": "array", "items": {"type": "string"}},
+								"env_vars": {"type": "object", "additionalProperties": {"type": "string"}},
+								"secrets": {"type": "object", "additionalProperties": {"type": "string"}},
+								"description": {"type": "string"}
+							}
+						}
+					}
+				}
+			}
+		},
+		"secrets": {
+			"type": "object",
+			"additionalProperties": {"type": "string"}
+		}
+	}
+}`
+
+// validateConfigSchema checks the raw YAML against configSchemaJSON,
+// reporting failures with the line/column of the offending YAML node.
+func validateConfigSchema(data []byte) error {
+	var generic interface{}
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return fmt.Errorf("could not parse YAML config file: %v", err)
+	}
+
+	jsonBytes, err := json.Marshal(generic)
+	if err != nil {
+		return fmt.Errorf("could not convert config to JSON for validation: %v", err)
+	}
+
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewStringLoader(configSchemaJSON),
+		gojsonschema.NewBytesLoader(jsonBytes),
+	)
+	if err != nil {
+		return fmt.Errorf("could not validate config against schema: %v", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	var root yaml.Node
+	_ = yaml.Unmarshal(data, &root) // best-effort, only used to locate line/column
+
+	var msgs []string
+	for _, re := range result.Errors() {
+		if node := locateSchemaNode(&root, fieldPathSegments(re.Field())); node != nil && node.Line > 0 {
+			msgs = append(msgs, fmt.Sprintf("line %d, column %d: %s", node.Line, node.Column, re.Description()))
+		} else {
+			msgs = append(msgs, re.String())
+		}
+	}
+	return fmt.Errorf("config schema validation failed:\n%s", strings.Join(msgs, "\n"))
+}
+
+func fieldPathSegments(field string) []string {
+	field = strings.TrimPrefix(field, "(root)")
+	field = strings.TrimPrefix(field, ".")
+	if field == "" {
+		return nil
+	}
+	return strings.Split(field, ".")
+}
+
+func locateSchemaNode(root *yaml.Node, segments []string) *yaml.Node {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, seg := range segments {
+		if node.Kind != yaml.MappingNode {
+			return node
+		}
+		value := findMappingValue(node, seg)
+		if value == nil {
+			return node
+		}
+		node = value
+	}
+	return node
+}
+
+func findMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// decryptConfigNodes decrypts !sops/!age-tagged scalars in place and
+// resolves !secretRef scalars against the (now-decrypted) top-level
+// secrets: block, so the final yaml.Node.Decode sees only plain strings.
+func decryptConfigNodes(root *yaml.Node) error {
+	doc := root
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+
+	secrets := map[string]string{}
+	if secretsNode := findMappingValue(doc, "secrets"); secretsNode != nil {
+		for i := 0; i < len(secretsNode.Content); i += 2 {
+			value := secretsNode.Content[i+1]
+			if err := decryptScalar(value); err != nil {
+				return err
+			}
+			secrets[secretsNode.Content[i].Value] = value.Value
+		}
+	}
+
+	return resolveConfigTree(doc, secrets)
+}
+
+func resolveConfigTree(node *yaml.Node, secrets map[string]string) error {
+	if node == nil {
+		return nil
+	}
+
+	if node.Kind == yaml.ScalarNode {
+		if node.Tag == "!secretRef" {
+			value, ok := secrets[node.Value]
+			if !ok {
+				return fmt.Errorf("secretRef %q has no matching entry in the secrets: block", node.Value)
+			}
+			node.Value, node.Tag = value, "!!str"
+			return nil
+		}
+		return decryptScalar(node)
+	}
+
+	for _, child := range node.Content {
+		if err := resolveConfigTree(child, secrets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decryptScalar(node *yaml.Node) error {
+	switch node.Tag {
+	case "!sops":
+		plaintext, err := sopsDecryptValue(node.Value)
+		if err != nil {
+			return err
+		}
+		node.Value, node.Tag = plaintext, "!!str"
+	case "!age":
+		plaintext, err := ageDecryptValue(node.Value)
+		if err != nil {
+			return err
+		}
+		node.Value, node.Tag = plaintext, "!!str"
+	}
+	return nil
+}
+
+// sopsDecryptValue decrypts a !sops-tagged scalar. Each value is expected to
+// be a standalone sops-encrypted JSON envelope of the form {"value": "..."}.
+func sopsDecryptValue(ciphertext string) (string, error) {
+	plaintext, err := decrypt.Data([]byte(ciphertext), "json")
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt sops value: %v", err)
+	}
+
+	var payload struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(plaintext, &payload); err != nil {
+		return "", fmt.Errorf("could not parse decrypted sops payload: %v", err)
+	}
+	return payload.Value, nil
+}
+
+// ageDecryptValue decrypts a !age-tagged scalar (an armored age ciphertext)
+// using the identities in $AGE_IDENTITY, or ~/.config/sops/age/keys.txt.
+func ageDecryptValue(ciphertext string) (string, error) {
+	identityPath := os.Getenv("AGE_IDENTITY")
+	if identityPath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not determine home directory for age identity: %v", err)
+		}
+		identityPath = filepath.Join(home, ".config", "sops", "age", "keys.txt")
+	}
+
+	identityFile, err := os.Open(identityPath)
+	if err != nil {
+		return "", fmt.Errorf("could not open age identity file %s: %v", identityPath, err)
+	}
+	defer identityFile.Close()
+
+	identities, err := age.ParseIdentities(identityFile)
+	if err != nil {
+		return "", fmt.Errorf("could not parse age identities: %v", err)
+	}
+
+	r, err := age.Decrypt(armor.NewReader(strings.NewReader(ciphertext)), identities...)
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt age value: %v", err)
+	}
+
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("could not read decrypted age value: %v", err)
+	}
+	return string(plaintext), nil
+}
+
+// ConfigLoader loads and schema-validates the config file, and watches it
+// for changes so the TUI can hot-reload without restarting.
+type ConfigLoader struct {
+	path    string
+	watcher *fsnotify.Watcher
+}
+
+// NewConfigLoader starts watching path for changes.
+func NewConfigLoader(path string) (*ConfigLoader, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("could not create config watcher: %v", err)
+	}
+
+	if err := watcher.Add(path); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("could not watch %s: %v", path, err)
+	}
+
+	return &ConfigLoader{path: path, watcher: watcher}, nil
+}
+
+// Load re-reads, validates and decrypts the config file.
+func (l *ConfigLoader) Load() (Config, error) {
+	return LoadConfig(l.path)
+}
+
+// watchCmd waits for the next write to the config file and reloads it,
+// re-arming itself so the TUI keeps picking up changes for its whole
+// lifetime.
+//
+// Most editors (vim, sed -i, and most config-management/atomic-deploy
+// tooling) save by writing a temp file and renaming it over the original,
+// which delivers a Remove for the watched inode rather than a Write and
+// stops the watch dead. On Remove/Rename we re-Add the path so the watch
+// picks up the new inode left behind by the rename; the Add itself may
+// briefly fail if the rename hasn't landed yet, in which case we just wait
+// for the next event instead of treating it as fatal.
+func (l *ConfigLoader) watchCmd() tea.Cmd {
+	return func() tea.Msg {
+		for {
+			select {
+			case event, ok := <-l.watcher.Events:
+				if !ok {
+					return nil
+				}
+
+				if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+					if err := l.watcher.Add(l.path); err != nil {
+						continue
+					}
+				} else if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+
+				config, err := l.Load()
+				if err != nil {
+					return configErrorMsg(err.Error())
+				}
+				return configReloadedMsg{config: config}
+
+			case err, ok := <-l.watcher.Errors:
+				if !ok {
+					return nil
+				}
+				return configErrorMsg(err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+var matchStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFEB3B"))
+
+// listEntry is one fuzzy-filterable row. display is exactly what gets
+// rendered for the row, so that a match's MatchedIndexes line up with the
+// runes the user actually sees highlighted.
+type listEntry struct {
+	key     string
+	display string
+}
+
+// fuzzyScore reorders entries by how well they match query, returning every
+// entry (unscored, in original order) when query is empty.
+func fuzzyScore(entries []listEntry, query string) []fuzzy.Match {
+	targets := make([]string, len(entries))
+	for i, e := range entries {
+		targets[i] = e.display
+	}
+
+	if query == "" {
+		matches := make([]fuzzy.Match, len(entries))
+		for i, t := range targets {
+			matches[i] = fuzzy.Match{Str: t, Index: i}
+		}
+		return matches
+	}
+
+	return fuzzy.Find(query, targets)
+}
+
+// highlightMatches renders s with the runes starting at the given byte
+// offsets (as fuzzy.Match reports them) styled to stand out, for showing why
+// a fuzzy-filtered row matched.
+func highlightMatches(s string, matched []int) string {
+	if len(matched) == 0 {
+		return s
+	}
+
+	matchedSet := make(map[int]bool, len(matched))
+	for _, i := range matched {
+		matchedSet[i] = true
+	}
+
+	var b strings.Builder
+	for i, r := range s {
+		if matchedSet[i] {
+			b.WriteString(matchStyle.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// renderFilterBar shows the active filter input, if any.
+func (m ServerModel) renderFilterBar() string {
+	if !m.filterOn {
+		return ""
+	}
+	return inputStyle.Render(m.filterInput.View()) + "\n\n"
+}
+
+// renderMatchList renders a cursor-navigable, fuzzy-highlighted list.
+func renderMatchList(matches []fuzzy.Match, cursor int) string {
+	var s string
+	for i, match := range matches {
+		line := highlightMatches(match.Str, match.MatchedIndexes)
+
+		cursorMark := " "
+		if cursor == i {
+			cursorMark = ">"
+		}
+
+		if cursor == i {
+			s += selectedItemStyle.Render(fmt.Sprintf("%s %s", cursorMark, line)) + "\n"
+		} else {
+			s += itemStyle.Render(fmt.Sprintf("%s %s", cursorMark, line)) + "\n"
+		}
+	}
+	return s
+}
+
+func categoryEntries(cfg Config, names []string) []listEntry {
+	entries := make([]listEntry, len(names))
+	for i, name := range names {
+		display := name
+		if desc := cfg.Categories[name].Description; desc != "" {
+			display = fmt.Sprintf("%s - %s", name, desc)
+		}
+		entries[i] = listEntry{key: name, display: display}
+	}
+	return entries
+}
+
+func serverEntries(cfg Config, category string, names []string) []listEntry {
+	entries := make([]listEntry, len(names))
+	servers := cfg.Categories[category].Servers
+	for i, name := range names {
+		display := name
+		if desc := servers[name].Description; desc != "" {
+			display = fmt.Sprintf("%s - %s", name, desc)
+		}
+		entries[i] = listEntry{key: name, display: display}
+	}
+	return entries
+}
+
+// allServerEntry identifies a server across every category, for the flat
+// ctrl+r "jump anywhere" view.
+type allServerEntry struct {
+	category string
+	server   string
+}
+
+// allServerEntries flattens every category's servers into one sorted,
+// filterable list.
+func allServerEntries(cfg Config, categoryNames []string) ([]allServerEntry, []listEntry) {
+	var all []allServerEntry
+	var entries []listEntry
+
+	for _, category := range categoryNames {
+		serverCfg := cfg.Categories[category]
+		var names []string
+		for name := range serverCfg.Servers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			display := fmt.Sprintf("%s / %s", category, name)
+			if desc := serverCfg.Servers[name].Description; desc != "" {
+				display = fmt.Sprintf("%s - %s", display, desc)
+			}
+			all = append(all, allServerEntry{category: category, server: name})
+			entries = append(entries, listEntry{key: name, display: display})
+		}
+	}
+
+	return all, entries
+}
@@ -8,13 +8,23 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
 	"gopkg.in/yaml.v3"
 )
 
+// program is set in runTUI() once the Bubble Tea program is created, so that
+// goroutines streaming script output can send messages into it with
+// program.Send instead of returning a single Cmd result.
+var program *tea.Program
+
 // Define some basic styles
 var (
 	titleStyle = lipgloss.NewStyle().
@@ -35,11 +45,14 @@ var (
 // Config represents our application configuration
 type Config struct {
 	Categories map[string]CategoryConfig `yaml:"categories"`
+	Secrets    map[string]string         `yaml:"secrets,omitempty"` // decrypted on load; referenced via !secretRef
 }
 
 // CategoryConfig represents a category (logging or metrics)
 type CategoryConfig struct {
 	Description string                  `yaml:"description,omitempty"`
+	VaultAddr   string                  `yaml:"vault_addr,omitempty"`  // Vault address for secrets in this category's servers
+	Parallelism int                     `yaml:"parallelism,omitempty"` // Max concurrent scripts for multi-server runs; default runtime.NumCPU()
 	Servers     map[string]ServerConfig `yaml:"servers"`
 }
 
@@ -49,6 +62,7 @@ type ServerConfig struct {
 	ScriptPath  string            `yaml:"script_path,omitempty"` // For backward compatibility
 	ScriptArgs  []string          `yaml:"script_args,omitempty"` // For backward compatibility
 	EnvVars     map[string]string `yaml:"env_vars,omitempty"`    // Still useful for both script types
+	Secrets     map[string]string `yaml:"secrets,omitempty"`     // envVarName -> vault://mount/path#field reference
 	Description string            `yaml:"description,omitempty"`
 }
 
@@ -59,7 +73,11 @@ const (
 	StateVaultTokenInput AppState = iota
 	StateCategorySelection
 	StateServerSelection
-	StateScriptOutput
+	StateAllServers
+	StateMultiServerSelection
+	StateMultiRunning
+	StateScriptRunning
+	StateScriptFinished
 )
 
 // ServerModel represents the state of our application
@@ -78,10 +96,43 @@ type ServerModel struct {
 	vaultToken       string
 	vaultTokenInput  string
 	pasteError       string
+	vaultErrMessage  string
+	scriptErrMessage string
+
+	viewport    viewport.Model
+	width       int
+	height      int
+	followTail  bool
+	runningCmd  *exec.Cmd
+	scriptStart time.Time
+	scriptExit  error
+
+	filterQuery string
+	filterOn    bool
+	filterInput textinput.Model
+	catMatches  []fuzzy.Match
+	srvMatches  []fuzzy.Match
+
+	allEntries []allServerEntry
+	allMatches []fuzzy.Match
+	allCursor  int
+
+	multiSelected map[string]bool
+	multiCursor   int
+	multiOrder    []string
+	multiStatuses map[string]*serverRunStatus
+	multiActive   bool
+	multiDrill    bool
+
+	configLoader     *ConfigLoader
+	reloadErrMessage string
 }
 
 // Init initializes the model
 func (m ServerModel) Init() tea.Cmd {
+	if m.configLoader != nil {
+		return tea.Batch(checkVaultToken, m.configLoader.watchCmd())
+	}
 	return checkVaultToken
 }
 
@@ -109,6 +160,11 @@ func pasteFromClipboard(model ServerModel) (ServerModel, error) {
 func (m ServerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// A config reload error is shown as a dismissible banner rather than
+		// blocking the TUI, so any keypress clears it and resumes normal
+		// navigation. A fatal startup error (m.errorMessage) is left alone.
+		m.reloadErrMessage = ""
+
 		switch m.state {
 		case StateVaultTokenInput:
 			m.pasteError = "" // Clear any paste error on keypress
@@ -147,68 +203,306 @@ func (m ServerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case StateCategorySelection:
+			if m.filterOn {
+				switch msg.String() {
+				case "ctrl+c":
+					return m, tea.Quit
+				case "esc":
+					m.filterOn = false
+					return m, nil
+				case "up", "ctrl+p":
+					if m.categoryCursor > 0 {
+						m.categoryCursor--
+					}
+				case "down", "ctrl+n":
+					if m.categoryCursor < len(m.catMatches)-1 {
+						m.categoryCursor++
+					}
+				case "enter":
+					if len(m.catMatches) == 0 {
+						return m, nil
+					}
+					m.filterOn = false
+					return enterCategory(m, m.categoryNames[m.catMatches[m.categoryCursor].Index])
+				default:
+					var cmd tea.Cmd
+					m.filterInput, cmd = m.filterInput.Update(msg)
+					m.filterQuery = m.filterInput.Value()
+					m.catMatches = fuzzyScore(categoryEntries(m.config, m.categoryNames), m.filterQuery)
+					m.categoryCursor = 0
+					return m, cmd
+				}
+			} else {
+				switch msg.String() {
+				case "ctrl+c", "q":
+					return m, tea.Quit
+				case "up", "k":
+					if m.categoryCursor > 0 {
+						m.categoryCursor--
+					}
+				case "down", "j":
+					if m.categoryCursor < len(m.catMatches)-1 {
+						m.categoryCursor++
+					}
+				case "/":
+					m.filterOn = true
+					m.filterInput = textinput.New()
+					m.filterInput.Prompt = "/"
+					m.filterInput.Focus()
+					m.filterInput.SetValue(m.filterQuery)
+					return m, textinput.Blink
+				case "ctrl+r":
+					return enterAllServers(m)
+				case "enter":
+					if len(m.catMatches) == 0 {
+						return m, nil
+					}
+					return enterCategory(m, m.categoryNames[m.catMatches[m.categoryCursor].Index])
+				}
+			}
+
+		case StateServerSelection:
+			if m.filterOn {
+				switch msg.String() {
+				case "ctrl+c":
+					return m, tea.Quit
+				case "esc":
+					m.filterOn = false
+					return m, nil
+				case "up", "ctrl+p":
+					if m.serverCursor > 0 {
+						m.serverCursor--
+					}
+				case "down", "ctrl+n":
+					if m.serverCursor < len(m.srvMatches)-1 {
+						m.serverCursor++
+					}
+				case "enter":
+					if len(m.srvMatches) == 0 {
+						return m, nil
+					}
+					m.filterOn = false
+					server := m.serverNames[m.srvMatches[m.serverCursor].Index]
+					return startScript(m, m.selectedCategory, server)
+				default:
+					var cmd tea.Cmd
+					m.filterInput, cmd = m.filterInput.Update(msg)
+					m.filterQuery = m.filterInput.Value()
+					m.srvMatches = fuzzyScore(serverEntries(m.config, m.selectedCategory, m.serverNames), m.filterQuery)
+					m.serverCursor = 0
+					return m, cmd
+				}
+			} else {
+				switch msg.String() {
+				case "ctrl+c", "q":
+					return m, tea.Quit
+				case "up", "k":
+					if m.serverCursor > 0 {
+						m.serverCursor--
+					}
+				case "down", "j":
+					if m.serverCursor < len(m.srvMatches)-1 {
+						m.serverCursor++
+					}
+				case "/":
+					m.filterOn = true
+					m.filterInput = textinput.New()
+					m.filterInput.Prompt = "/"
+					m.filterInput.Focus()
+					m.filterInput.SetValue(m.filterQuery)
+					return m, textinput.Blink
+				case "ctrl+r":
+					return enterAllServers(m)
+				case "enter":
+					if len(m.srvMatches) == 0 {
+						return m, nil
+					}
+					server := m.serverNames[m.srvMatches[m.serverCursor].Index]
+					return startScript(m, m.selectedCategory, server)
+
+				case " ":
+					m.multiSelected = map[string]bool{}
+					m.multiCursor = 0
+					m.state = StateMultiServerSelection
+					return m, nil
+
+				case "b", "backspace", "esc":
+					// Go back to category selection
+					m.state = StateCategorySelection
+					return m, nil
+				}
+			}
+
+		case StateMultiServerSelection:
 			switch msg.String() {
 			case "ctrl+c", "q":
 				return m, tea.Quit
 			case "up", "k":
-				if m.categoryCursor > 0 {
-					m.categoryCursor--
+				if m.multiCursor > 0 {
+					m.multiCursor--
 				}
 			case "down", "j":
-				if m.categoryCursor < len(m.categoryNames)-1 {
-					m.categoryCursor++
+				if m.multiCursor < len(m.serverNames)-1 {
+					m.multiCursor++
 				}
+			case " ":
+				server := m.serverNames[m.multiCursor]
+				m.multiSelected[server] = !m.multiSelected[server]
 			case "enter":
-				m.selectedCategory = m.categoryNames[m.categoryCursor]
+				return startMultiRun(m)
+			case "b", "backspace", "esc":
+				m.state = StateServerSelection
+				return m, nil
+			}
 
-				// Update server names based on selected category
-				var serverNames []string
-				for name := range m.config.Categories[m.selectedCategory].Servers {
-					serverNames = append(serverNames, name)
+		case StateMultiRunning:
+			if m.multiDrill {
+				switch msg.String() {
+				case "ctrl+c", "q":
+					return m, tea.Quit
+				case "b", "backspace", "esc":
+					m.multiDrill = false
+					return m, nil
+				case "f":
+					m.followTail = !m.followTail
+					if m.followTail {
+						m.viewport.GotoBottom()
+					}
+					return m, nil
+				default:
+					var cmd tea.Cmd
+					m.viewport, cmd = m.viewport.Update(msg)
+					return m, cmd
 				}
-				sort.Strings(serverNames)
-				m.serverNames = serverNames
-				m.serverCursor = 0 // Reset server cursor
-
-				m.state = StateServerSelection
 			}
 
-		case StateServerSelection:
 			switch msg.String() {
 			case "ctrl+c", "q":
 				return m, tea.Quit
 			case "up", "k":
-				if m.serverCursor > 0 {
-					m.serverCursor--
+				if m.multiCursor > 0 {
+					m.multiCursor--
 				}
 			case "down", "j":
-				if m.serverCursor < len(m.serverNames)-1 {
-					m.serverCursor++
+				if m.multiCursor < len(m.multiOrder)-1 {
+					m.multiCursor++
 				}
 			case "enter":
-				m.selectedServer = m.serverNames[m.serverCursor]
-				m.state = StateScriptOutput
-
-				// Get the server config from the selected category
-				serverConfig := m.config.Categories[m.selectedCategory].Servers[m.selectedServer]
-				return m, runServerScript(m.selectedServer, serverConfig, m.vaultToken)
-
+				server := m.multiOrder[m.multiCursor]
+				status := m.multiStatuses[server]
+				if status != nil && status.state != "pending" {
+					m.multiDrill = true
+					m.followTail = status.state == "running"
+					content := status.output
+					if status.err != nil {
+						content += "\n" + errorStyle.Render(fmt.Sprintf("error: %v", status.err))
+					}
+					m.viewport.SetContent(content)
+					if m.followTail {
+						m.viewport.GotoBottom()
+					} else {
+						m.viewport.GotoTop()
+					}
+				}
 			case "b", "backspace", "esc":
-				// Go back to category selection
-				m.state = StateCategorySelection
+				m.state = StateServerSelection
 				return m, nil
 			}
 
-		case StateScriptOutput:
+		case StateAllServers:
+			if m.filterOn {
+				switch msg.String() {
+				case "ctrl+c":
+					return m, tea.Quit
+				case "esc":
+					m.filterOn = false
+					return m, nil
+				case "up", "ctrl+p":
+					if m.allCursor > 0 {
+						m.allCursor--
+					}
+				case "down", "ctrl+n":
+					if m.allCursor < len(m.allMatches)-1 {
+						m.allCursor++
+					}
+				case "enter":
+					if len(m.allMatches) == 0 {
+						return m, nil
+					}
+					m.filterOn = false
+					entry := m.allEntries[m.allMatches[m.allCursor].Index]
+					return startScript(m, entry.category, entry.server)
+				default:
+					var cmd tea.Cmd
+					m.filterInput, cmd = m.filterInput.Update(msg)
+					m.filterQuery = m.filterInput.Value()
+					_, entries := allServerEntries(m.config, m.categoryNames)
+					m.allMatches = fuzzyScore(entries, m.filterQuery)
+					m.allCursor = 0
+					return m, cmd
+				}
+			} else {
+				switch msg.String() {
+				case "ctrl+c", "q":
+					return m, tea.Quit
+				case "up", "k":
+					if m.allCursor > 0 {
+						m.allCursor--
+					}
+				case "down", "j":
+					if m.allCursor < len(m.allMatches)-1 {
+						m.allCursor++
+					}
+				case "/":
+					m.filterOn = true
+					m.filterInput = textinput.New()
+					m.filterInput.Prompt = "/"
+					m.filterInput.Focus()
+					m.filterInput.SetValue(m.filterQuery)
+					return m, textinput.Blink
+				case "enter":
+					if len(m.allMatches) == 0 {
+						return m, nil
+					}
+					entry := m.allEntries[m.allMatches[m.allCursor].Index]
+					return startScript(m, entry.category, entry.server)
+				case "b", "backspace", "esc":
+					// Go back to category selection
+					m.state = StateCategorySelection
+					return m, nil
+				}
+			}
+
+		case StateScriptRunning, StateScriptFinished:
 			switch msg.String() {
 			case "ctrl+c", "q":
 				return m, tea.Quit
+			case "ctrl+k":
+				if m.state == StateScriptRunning && m.runningCmd != nil && m.runningCmd.Process != nil {
+					m.runningCmd.Process.Signal(syscall.SIGINT)
+				}
 			case "b", "backspace", "esc":
-				// Go back to server selection
-				m.state = StateServerSelection
-				m.runOutput = ""
-				m.hasError = false
-				return m, nil
+				if m.state == StateScriptFinished {
+					// Go back to server selection
+					m.state = StateServerSelection
+					m.runOutput = ""
+					m.hasError = false
+					m.runningCmd = nil
+					return m, nil
+				}
+			case "f":
+				m.followTail = !m.followTail
+			default:
+				var cmd tea.Cmd
+				m.viewport, cmd = m.viewport.Update(msg)
+				// Manual scrolling opts the user out of auto-follow until
+				// they explicitly jump back to the bottom or toggle "f".
+				if m.viewport.AtBottom() {
+					m.followTail = true
+				} else {
+					m.followTail = false
+				}
+				return m, cmd
 			}
 		}
 
@@ -221,18 +515,157 @@ func (m ServerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state = StateVaultTokenInput
 		return m, nil
 
-	case scriptOutputMsg:
-		m.runOutput = string(msg)
+	case vaultErrorMsg:
+		m.vaultErrMessage = string(msg)
+		m.state = StateScriptFinished
 		return m, nil
 
 	case scriptErrorMsg:
-		m.runOutput = string(msg)
-		m.hasError = true
+		m.scriptErrMessage = string(msg)
+		m.state = StateScriptFinished
+		return m, nil
+
+	case scriptStartedMsg:
+		m.runningCmd = msg.cmd
+		return m, nil
+
+	case scriptLineMsg:
+		m.runOutput += string(msg) + "\n"
+		m.viewport.SetContent(m.runOutput)
+		if m.followTail {
+			m.viewport.GotoBottom()
+		}
+		return m, nil
+
+	case scriptDoneMsg:
+		m.state = StateScriptFinished
+		m.scriptExit = msg.err
+		m.hasError = msg.err != nil
+		if m.followTail {
+			m.viewport.GotoBottom()
+		}
+		return m, nil
+
+	case scriptTickMsg:
+		if m.state == StateScriptRunning {
+			return m, scriptTickCmd()
+		}
+		return m, nil
+
+	case multiStartedMsg:
+		if status, ok := m.multiStatuses[msg.server]; ok {
+			status.state = "running"
+			status.start = time.Now()
+		}
+		return m, nil
+
+	case multiLineMsg:
+		if status, ok := m.multiStatuses[msg.server]; ok {
+			if status.output != "" {
+				status.output += "\n"
+			}
+			status.output += msg.line
+
+			if m.multiDrill && m.multiOrder[m.multiCursor] == msg.server {
+				m.viewport.SetContent(status.output)
+				if m.followTail {
+					m.viewport.GotoBottom()
+				}
+			}
+		}
+		return m, nil
+
+	case runResultMsg:
+		if status, ok := m.multiStatuses[msg.server]; ok {
+			status.elapsed = time.Since(status.start)
+			status.err = msg.err
+			if msg.err != nil {
+				status.state = "failed"
+			} else {
+				status.state = "ok"
+			}
+		}
+		return m, nil
+
+	case multiDoneMsg:
+		m.multiActive = false
+		return m, nil
+
+	case multiTickMsg:
+		if m.multiActive {
+			for _, status := range m.multiStatuses {
+				if status.state == "running" {
+					status.elapsed = time.Since(status.start)
+				}
+			}
+			return m, multiTickCmd()
+		}
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		headerHeight := 6
+		footerHeight := 2
+		vpHeight := m.height - headerHeight - footerHeight
+		if vpHeight < 3 {
+			vpHeight = 3
+		}
+		m.viewport.Width = m.width
+		m.viewport.Height = vpHeight
 		return m, nil
 
 	case configErrorMsg:
-		m.errorMessage = string(msg)
-		return m, tea.Quit
+		// Only ever produced by the config file watcher (ConfigLoader.watchCmd); the config
+		// is already loaded and running, so a bad edit just surfaces a
+		// dismissible banner instead of taking down the TUI.
+		m.reloadErrMessage = string(msg)
+		if m.configLoader != nil {
+			return m, m.configLoader.watchCmd()
+		}
+		return m, nil
+
+	case configReloadedMsg:
+		m.config = msg.config
+
+		var categoryNames []string
+		for name := range msg.config.Categories {
+			categoryNames = append(categoryNames, name)
+		}
+		sort.Strings(categoryNames)
+		m.categoryNames = categoryNames
+		m.catMatches = fuzzyScore(categoryEntries(m.config, categoryNames), m.filterQuery)
+		m.categoryCursor = 0
+
+		// The selected category (and its servers) may have shrunk or
+		// disappeared entirely, so rescore/reset the same way a filter
+		// keystroke would rather than leaving stale indexes that can run
+		// past the new slices.
+		if category, ok := m.config.Categories[m.selectedCategory]; ok {
+			var serverNames []string
+			for name := range category.Servers {
+				serverNames = append(serverNames, name)
+			}
+			sort.Strings(serverNames)
+			m.serverNames = serverNames
+			m.srvMatches = fuzzyScore(serverEntries(m.config, m.selectedCategory, serverNames), m.filterQuery)
+		} else {
+			m.serverNames = nil
+			m.srvMatches = nil
+		}
+		m.serverCursor = 0
+
+		all, entries := allServerEntries(m.config, categoryNames)
+		m.allEntries = all
+		m.allMatches = fuzzyScore(entries, m.filterQuery)
+		m.allCursor = 0
+
+		m.reloadErrMessage = ""
+
+		if m.configLoader != nil {
+			return m, m.configLoader.watchCmd()
+		}
+		return m, nil
 	}
 
 	return m, nil
@@ -244,6 +677,17 @@ func (m ServerModel) View() string {
 		return errorStyle.Render(fmt.Sprintf("Configuration Error: %s", m.errorMessage))
 	}
 
+	banner := ""
+	if m.reloadErrMessage != "" {
+		banner = errorStyle.Render(fmt.Sprintf("Config reload failed: %s (press any key to dismiss)", m.reloadErrMessage)) + "\n\n"
+	}
+
+	return banner + m.renderState()
+}
+
+// renderState renders the current state's view; split out from View so a
+// reload-error banner can be prepended without touching each case.
+func (m ServerModel) renderState() string {
 	switch m.state {
 	case StateVaultTokenInput:
 		s := titleStyle.Render("Vault Authentication") + "\n\n"
@@ -267,76 +711,166 @@ func (m ServerModel) View() string {
 	case StateCategorySelection:
 		// Category selection view
 		s := titleStyle.Render("Category Selection") + "\n\n"
+		s += m.renderFilterBar()
 		s += "Select a category:\n\n"
+		s += renderMatchList(m.catMatches, m.categoryCursor)
+
+		s += "\n"
+		s += infoStyle.Render("/ to filter, ctrl+r to jump to any server, arrow keys or j/k to navigate, enter to select, q to quit")
+		return s
+
+	case StateServerSelection:
+		// Server selection view
+		s := titleStyle.Render("Server Selection") + "\n\n"
+		s += categoryStyle.Render(fmt.Sprintf("Category: %s", m.selectedCategory)) + "\n\n"
+		s += m.renderFilterBar()
+		s += "Select a server region to run script:\n\n"
+		s += renderMatchList(m.srvMatches, m.serverCursor)
 
-		for i, categoryName := range m.categoryNames {
+		s += "\n"
+		s += infoStyle.Render("/ to filter, ctrl+r to jump to any server, arrow keys or j/k to navigate, enter to select, b to go back, q to quit")
+		return s
+
+	case StateAllServers:
+		// Flat, filterable view across every category's servers
+		s := titleStyle.Render("All Servers") + "\n\n"
+		s += m.renderFilterBar()
+		s += "Select a server to run script:\n\n"
+		s += renderMatchList(m.allMatches, m.allCursor)
+
+		s += "\n"
+		s += infoStyle.Render("/ to filter, arrow keys or j/k to navigate, enter to select, b to go back, q to quit")
+		return s
+
+	case StateMultiServerSelection:
+		s := titleStyle.Render("Multi-Server Selection") + "\n\n"
+		s += categoryStyle.Render(fmt.Sprintf("Category: %s", m.selectedCategory)) + "\n\n"
+		s += "Toggle servers to run concurrently with space, enter to run:\n\n"
+
+		for i, serverName := range m.serverNames {
 			cursor := " "
-			if m.categoryCursor == i {
+			if m.multiCursor == i {
 				cursor = ">"
 			}
 
-			categoryInfo := categoryName
-			if desc := m.config.Categories[categoryName].Description; desc != "" {
-				categoryInfo = fmt.Sprintf("%s - %s", categoryName, desc)
+			mark := "[ ]"
+			if m.multiSelected[serverName] {
+				mark = "[x]"
 			}
 
-			if m.categoryCursor == i {
-				s += selectedItemStyle.Render(fmt.Sprintf("%s %s", cursor, categoryInfo)) + "\n"
+			line := fmt.Sprintf("%s %s %s", cursor, mark, serverName)
+			if m.multiCursor == i {
+				s += selectedItemStyle.Render(line) + "\n"
 			} else {
-				s += itemStyle.Render(fmt.Sprintf("%s %s", cursor, categoryInfo)) + "\n"
+				s += itemStyle.Render(line) + "\n"
 			}
 		}
 
 		s += "\n"
-		s += infoStyle.Render("Use arrow keys or j/k to navigate, enter to select, q to quit")
+		s += infoStyle.Render("space to toggle, enter to run selected, b to go back, q to quit")
 		return s
 
-	case StateServerSelection:
-		// Server selection view
-		s := titleStyle.Render("Server Selection") + "\n\n"
+	case StateMultiRunning:
+		s := titleStyle.Render("Multi-Server Run") + "\n\n"
 		s += categoryStyle.Render(fmt.Sprintf("Category: %s", m.selectedCategory)) + "\n\n"
-		s += "Select a server region to run script:\n\n"
 
-		for i, serverName := range m.serverNames {
+		if m.multiDrill {
+			follow := "off"
+			if m.followTail {
+				follow = "on"
+			}
+			s += fmt.Sprintf("Server: %s\n\n", m.multiOrder[m.multiCursor])
+			s += m.viewport.View() + "\n\n"
+			s += infoStyle.Render(fmt.Sprintf("j/k/PgUp/PgDn to scroll, f to toggle follow tail (%s), b to go back, q to quit", follow))
+			return s
+		}
+
+		ok, failed, pending := 0, 0, 0
+		for i, server := range m.multiOrder {
+			status := m.multiStatuses[server]
+
 			cursor := " "
-			if m.serverCursor == i {
+			if m.multiCursor == i {
 				cursor = ">"
 			}
 
-			serverInfo := serverName
-			if desc := m.config.Categories[m.selectedCategory].Servers[serverName].Description; desc != "" {
-				serverInfo = fmt.Sprintf("%s - %s", serverName, desc)
+			var statusLabel string
+			switch status.state {
+			case "ok":
+				statusLabel = promptStyle.Render("ok")
+				ok++
+			case "failed":
+				statusLabel = errorStyle.Render("failed")
+				failed++
+			case "running":
+				statusLabel = infoStyle.Render("running")
+				pending++
+			default:
+				statusLabel = infoStyle.Render("pending")
+				pending++
 			}
 
-			if m.serverCursor == i {
-				s += selectedItemStyle.Render(fmt.Sprintf("%s %s", cursor, serverInfo)) + "\n"
+			line := fmt.Sprintf("%s %-24s %-10s %s", cursor, server, statusLabel, status.elapsed.Round(time.Second))
+			if m.multiCursor == i {
+				s += selectedItemStyle.Render(line) + "\n"
 			} else {
-				s += itemStyle.Render(fmt.Sprintf("%s %s", cursor, serverInfo)) + "\n"
+				s += itemStyle.Render(line) + "\n"
 			}
 		}
 
 		s += "\n"
-		s += infoStyle.Render("Use arrow keys or j/k to navigate, enter to select, b to go back, q to quit")
+		if m.multiActive {
+			s += infoStyle.Render(fmt.Sprintf("running: %d ok, %d failed, %d pending/running", ok, failed, pending))
+		} else {
+			s += infoStyle.Render(fmt.Sprintf("done: %d ok, %d failed", ok, failed))
+		}
+
+		s += "\n\n"
+		s += infoStyle.Render("arrow keys or j/k to navigate, enter to view a server's output, b to go back, q to quit")
 		return s
 
-	case StateScriptOutput:
-		// Result view
+	case StateScriptRunning, StateScriptFinished:
+		// Streaming result view
 		s := titleStyle.Render("Script Output") + "\n\n"
 		s += categoryStyle.Render(fmt.Sprintf("Category: %s", m.selectedCategory)) + "\n"
-		s += fmt.Sprintf("Server: %s\n\n", m.selectedServer)
+		s += fmt.Sprintf("Server: %s\n", m.selectedServer)
 
-		if m.runOutput != "" {
-			if m.hasError {
-				s += errorStyle.Render("Error: " + m.runOutput)
-			} else {
-				s += m.runOutput
-			}
-		} else {
-			s += "Running script..."
+		if m.vaultErrMessage != "" {
+			s += "\n" + errorStyle.Render("Vault error: "+m.vaultErrMessage) + "\n\n"
+			s += infoStyle.Render("Press 'b' to go back to server selection, q to quit")
+			return s
 		}
 
+		if m.scriptErrMessage != "" {
+			s += "\n" + errorStyle.Render("Error: "+m.scriptErrMessage) + "\n\n"
+			s += infoStyle.Render("Press 'b' to go back to server selection, q to quit")
+			return s
+		}
+
+		elapsed := time.Since(m.scriptStart).Round(time.Second)
+		switch {
+		case m.state == StateScriptRunning:
+			s += infoStyle.Render(fmt.Sprintf("status: running (%s elapsed)", elapsed))
+		case m.scriptExit == nil:
+			s += infoStyle.Render(fmt.Sprintf("status: ok (%s elapsed)", elapsed))
+		default:
+			s += errorStyle.Render(fmt.Sprintf("status: failed: %v (%s elapsed)", m.scriptExit, elapsed))
+		}
 		s += "\n\n"
-		s += infoStyle.Render("Press 'b' to go back to server selection, q to quit")
+
+		s += m.viewport.View() + "\n\n"
+
+		follow := "off"
+		if m.followTail {
+			follow = "on"
+		}
+		hint := fmt.Sprintf("j/k/PgUp/PgDn to scroll, f to toggle follow tail (%s), q to quit", follow)
+		if m.state == StateScriptFinished {
+			hint = "Press 'b' to go back to server selection, " + hint
+		} else {
+			hint = fmt.Sprintf("j/k/PgUp/PgDn to scroll, f to toggle follow tail (%s), ctrl+k to interrupt, q to quit", follow)
+		}
+		s += infoStyle.Render(hint)
 		return s
 	}
 
@@ -344,11 +878,10 @@ func (m ServerModel) View() string {
 }
 
 // Custom messages for our commands
-type scriptOutputMsg string
-type scriptErrorMsg string
 type configErrorMsg string
 type vaultTokenMsg string
 type vaultTokenMissingMsg struct{}
+type vaultErrorMsg string
 
 // LoadConfig loads the configuration from a file
 func LoadConfig(path string) (Config, error) {
@@ -359,63 +892,106 @@ func LoadConfig(path string) (Config, error) {
 		return config, fmt.Errorf("could not read config file: %v", err)
 	}
 
-	err = yaml.Unmarshal(data, &config)
-	if err != nil {
+	if err := validateConfigSchema(data); err != nil {
+		return config, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
 		return config, fmt.Errorf("could not parse YAML config file: %v", err)
 	}
 
+	if err := decryptConfigNodes(&root); err != nil {
+		return config, fmt.Errorf("could not decrypt config secrets: %v", err)
+	}
+
+	if err := root.Decode(&config); err != nil {
+		return config, fmt.Errorf("could not decode config: %v", err)
+	}
+
 	return config, nil
 }
 
-// Function to run a script for a server
-func runServerScript(server string, config ServerConfig, vaultToken string) tea.Cmd {
-	return func() tea.Msg {
-		// Check if we have a direct script to run
-		if config.Script != "" {
-			// Create environment variables string for server and vault token
-			envVarsStr := fmt.Sprintf("export SERVER_REGION=%s; export VAULT_TOKEN=%s; ",
-				server, vaultToken)
-
-			// Add custom environment variables from config
-			for key, value := range config.EnvVars {
-				envVarsStr += fmt.Sprintf("export %s=%s; ", key, value)
-			}
+// enterCategory moves the model into server selection for the given
+// category, scoring its servers against the persisted filter query.
+func enterCategory(m ServerModel, category string) (ServerModel, tea.Cmd) {
+	m.selectedCategory = category
+
+	var serverNames []string
+	for name := range m.config.Categories[category].Servers {
+		serverNames = append(serverNames, name)
+	}
+	sort.Strings(serverNames)
+	m.serverNames = serverNames
+	m.serverCursor = 0
+	m.srvMatches = fuzzyScore(serverEntries(m.config, category, serverNames), m.filterQuery)
 
-			// Combine env vars with script
-			fullCommand := envVarsStr + config.Script
+	m.state = StateServerSelection
+	return m, nil
+}
 
-			// Run the command
-			cmd := exec.Command("bash", "-c", fullCommand)
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				return scriptErrorMsg(fmt.Sprintf("%v\n%s", err, output))
-			}
-			return scriptOutputMsg(output)
-		} else if config.ScriptPath != "" {
-			// For backward compatibility with the old script_path method
-			cmd := exec.Command(config.ScriptPath, config.ScriptArgs...)
-
-			// Set environment variables
-			env := os.Environ()
-			env = append(env, fmt.Sprintf("SERVER_REGION=%s", server))
-			env = append(env, fmt.Sprintf("VAULT_TOKEN=%s", vaultToken))
-
-			for key, value := range config.EnvVars {
-				env = append(env, fmt.Sprintf("%s=%s", key, value))
-			}
+// enterAllServers moves the model into the flat, filterable view across
+// every category's servers (ctrl+r from category or server selection).
+func enterAllServers(m ServerModel) (ServerModel, tea.Cmd) {
+	all, entries := allServerEntries(m.config, m.categoryNames)
+	m.allEntries = all
+	m.allMatches = fuzzyScore(entries, m.filterQuery)
+	m.allCursor = 0
+	m.state = StateAllServers
+	return m, nil
+}
 
-			cmd.Env = env
+// startScript transitions into StateScriptRunning and kicks off the
+// server's script, shared by server selection and the flat all-servers view.
+func startScript(m ServerModel, category, server string) (ServerModel, tea.Cmd) {
+	m.selectedCategory = category
+	m.selectedServer = server
+	m.state = StateScriptRunning
+	m.vaultErrMessage = ""
+	m.scriptErrMessage = ""
+	m.hasError = false
+	m.scriptExit = nil
+	m.followTail = true
+	m.scriptStart = time.Now()
+	m.viewport.SetContent("")
+
+	categoryConfig := m.config.Categories[category]
+	serverConfig := categoryConfig.Servers[server]
+	return m, tea.Batch(
+		streamServerScript(server, categoryConfig.VaultAddr, serverConfig, m.vaultToken),
+		scriptTickCmd(),
+	)
+}
 
-			// Run the command
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				return scriptErrorMsg(fmt.Sprintf("%v\n%s", err, output))
-			}
-			return scriptOutputMsg(output)
-		} else {
-			return scriptErrorMsg("No script or script_path defined for this server")
+// startMultiRun kicks off a bounded-concurrency run across every server the
+// user toggle-selected in StateMultiServerSelection.
+func startMultiRun(m ServerModel) (ServerModel, tea.Cmd) {
+	var servers []string
+	for name, selected := range m.multiSelected {
+		if selected {
+			servers = append(servers, name)
 		}
 	}
+	if len(servers) == 0 {
+		return m, nil
+	}
+	sort.Strings(servers)
+
+	m.multiOrder = servers
+	m.multiStatuses = make(map[string]*serverRunStatus, len(servers))
+	for _, name := range servers {
+		m.multiStatuses[name] = &serverRunStatus{state: "pending"}
+	}
+	m.multiCursor = 0
+	m.multiActive = true
+	m.multiDrill = false
+	m.state = StateMultiRunning
+
+	categoryConfig := m.config.Categories[m.selectedCategory]
+	return m, tea.Batch(
+		runMultiServers(categoryConfig, servers, m.vaultToken),
+		multiTickCmd(),
+	)
 }
 
 func getDefaultConfigPath() string {
@@ -452,6 +1028,14 @@ func initialSetup() (ServerModel, tea.Cmd) {
 	// Sort categories alphabetically for consistent display
 	sort.Strings(categoryNames)
 
+	// Hot-reload is best-effort: if the watcher can't be set up (e.g. the
+	// platform's inotify/kqueue limits), fall back to a config that simply
+	// doesn't live-reload rather than failing the whole app.
+	loader, err := NewConfigLoader(configPath)
+	if err != nil {
+		loader = nil
+	}
+
 	return ServerModel{
 		config:          config,
 		categoryNames:   categoryNames,
@@ -459,13 +1043,18 @@ func initialSetup() (ServerModel, tea.Cmd) {
 		vaultTokenInput: "",
 		categoryCursor:  0,
 		serverCursor:    0,
+		catMatches:      fuzzyScore(categoryEntries(config, categoryNames), ""),
+		configLoader:    loader,
 	}, nil
 }
 
-func main() {
+// runTUI launches the interactive Bubble Tea UI; this is the tool's default
+// behavior when invoked with no subcommand.
+func runTUI() error {
 	model, cmd := initialSetup()
 
 	p := tea.NewProgram(model)
+	program = p
 	p.EnterAltScreen()
 	defer p.ExitAltScreen()
 
@@ -474,7 +1063,14 @@ func main() {
 	}
 
 	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
+		return fmt.Errorf("error running program: %v", err)
+	}
+	return nil
+}
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// serverRunStatus tracks one server's progress through a multi-server run:
+// pending -> running -> ok|failed.
+type serverRunStatus struct {
+	state   string
+	start   time.Time
+	elapsed time.Duration
+	output  string
+	err     error
+}
+
+type multiStartedMsg struct{ server string }
+
+// multiLineMsg carries one line of a still-running server's combined
+// stdout/stderr, the same way scriptLineMsg does for the single-server view,
+// so the drill-in view stays live instead of only showing output once a
+// server finishes.
+type multiLineMsg struct {
+	server string
+	line   string
+}
+type runResultMsg struct {
+	server string
+	err    error
+}
+type multiDoneMsg struct{}
+type multiTickMsg time.Time
+
+// multiTickCmd drives a once-a-second re-render so elapsed times keep
+// counting up for servers still running.
+func multiTickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return multiTickMsg(t)
+	})
+}
+
+// runMultiServers fans out across servers with a worker pool bounded by
+// categoryConfig.Parallelism (default runtime.NumCPU), reporting progress
+// via program.Send rather than a single Cmd return value.
+func runMultiServers(categoryConfig CategoryConfig, servers []string, vaultToken string) tea.Cmd {
+	return func() tea.Msg {
+		parallelism := categoryConfig.Parallelism
+		if parallelism <= 0 {
+			parallelism = runtime.NumCPU()
+		}
+
+		sem := make(chan struct{}, parallelism)
+		var wg sync.WaitGroup
+
+		for _, server := range servers {
+			wg.Add(1)
+			sem <- struct{}{}
+
+			go func(server string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				program.Send(multiStartedMsg{server: server})
+
+				serverConfig := categoryConfig.Servers[server]
+				cmd, err := buildScriptCmd(server, categoryConfig.VaultAddr, serverConfig, vaultToken)
+				if err != nil {
+					program.Send(runResultMsg{server: server, err: err})
+					return
+				}
+
+				stdout, err := cmd.StdoutPipe()
+				if err != nil {
+					program.Send(runResultMsg{server: server, err: fmt.Errorf("could not attach stdout: %v", err)})
+					return
+				}
+				cmd.Stderr = cmd.Stdout // combine stderr into the same stream, in output order
+
+				if err := cmd.Start(); err != nil {
+					program.Send(runResultMsg{server: server, err: fmt.Errorf("could not start script: %v", err)})
+					return
+				}
+
+				streamMultiLines(server, stdout)
+				err = cmd.Wait()
+				program.Send(runResultMsg{server: server, err: err})
+			}(server)
+		}
+
+		go func() {
+			wg.Wait()
+			program.Send(multiDoneMsg{})
+		}()
+
+		return nil
+	}
+}
+
+// streamMultiLines reads r line by line, sending each as a multiLineMsg
+// until EOF, so a server's output accumulates in its status while the
+// script is still running rather than only appearing once it exits.
+func streamMultiLines(server string, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		program.Send(multiLineMsg{server: server, line: scanner.Text()})
+	}
+}
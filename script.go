@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Messages emitted while a server script runs under the TUI.
+type scriptStartedMsg struct{ cmd *exec.Cmd }
+type scriptLineMsg string
+type scriptDoneMsg struct{ err error }
+type scriptTickMsg time.Time
+
+// scriptErrorMsg reports a script setup failure that has nothing to do with
+// vault (missing script/script_path, pipe/start failures), so it isn't
+// confused for vaultErrorMsg and shown under a misleading "Vault error"
+// banner.
+type scriptErrorMsg string
+
+// scriptTickCmd drives a once-a-second re-render so the elapsed-time header
+// keeps counting up while a script is running.
+func scriptTickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return scriptTickMsg(t)
+	})
+}
+
+// buildScriptCmd resolves any vault:// secret references and assembles the
+// *exec.Cmd for a server's script, without starting it. All env vars --
+// resolved vault secrets, decrypted config values (!sops/!age/!secretRef),
+// and plain config.EnvVars alike -- are injected via cmd.Env only, never
+// interpolated into the shell string passed to "bash -c", so they can't leak
+// through process listings, shell history, or shell metacharacter expansion.
+func buildScriptCmd(server, vaultAddr string, config ServerConfig, vaultToken string) (*exec.Cmd, error) {
+	var secretEnv []string
+	if len(config.Secrets) > 0 {
+		resolver, err := newVaultResolver(vaultAddr, vaultToken)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved, err := resolver.resolveSecrets(config.Secrets)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range resolved {
+			secretEnv = append(secretEnv, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+
+	if config.Script != "" {
+		cmd := exec.Command("bash", "-c", config.Script)
+
+		env := os.Environ()
+		env = append(env, fmt.Sprintf("SERVER_REGION=%s", server))
+		env = append(env, fmt.Sprintf("VAULT_TOKEN=%s", vaultToken))
+		for key, value := range config.EnvVars {
+			env = append(env, fmt.Sprintf("%s=%s", key, value))
+		}
+		env = append(env, secretEnv...)
+		cmd.Env = env
+
+		return cmd, nil
+	}
+
+	if config.ScriptPath != "" {
+		cmd := exec.Command(config.ScriptPath, config.ScriptArgs...)
+
+		env := os.Environ()
+		env = append(env, fmt.Sprintf("SERVER_REGION=%s", server))
+		env = append(env, fmt.Sprintf("VAULT_TOKEN=%s", vaultToken))
+		for key, value := range config.EnvVars {
+			env = append(env, fmt.Sprintf("%s=%s", key, value))
+		}
+		env = append(env, secretEnv...)
+		cmd.Env = env
+
+		return cmd, nil
+	}
+
+	return nil, fmt.Errorf("no script or script_path defined for this server")
+}
+
+// streamServerScript starts the server's script and streams its combined
+// stdout/stderr into the running Bubble Tea program one line at a time via
+// program.Send, rather than blocking until the process exits.
+func streamServerScript(server, vaultAddr string, config ServerConfig, vaultToken string) tea.Cmd {
+	return func() tea.Msg {
+		cmd, err := buildScriptCmd(server, vaultAddr, config, vaultToken)
+		if err != nil {
+			var vaultErr *vaultResolveError
+			if errors.As(err, &vaultErr) {
+				return vaultErrorMsg(err.Error())
+			}
+			return scriptErrorMsg(err.Error())
+		}
+
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return scriptErrorMsg(fmt.Sprintf("could not attach stdout: %v", err))
+		}
+		cmd.Stderr = cmd.Stdout // combine stderr into the same stream, in output order
+
+		if err := cmd.Start(); err != nil {
+			return scriptErrorMsg(fmt.Sprintf("could not start script: %v", err))
+		}
+
+		go func() {
+			// Wait closes the pipe, so it must only run once streamLines
+			// has read everything up to EOF.
+			streamLines(stdout)
+			err := cmd.Wait()
+			program.Send(scriptDoneMsg{err: err})
+		}()
+
+		return scriptStartedMsg{cmd: cmd}
+	}
+}
+
+// streamLines reads r line by line, sending each as a scriptLineMsg until EOF.
+func streamLines(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		program.Send(scriptLineMsg(scanner.Text()))
+	}
+}
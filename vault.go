@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// vaultRef is a parsed "vault://<mount>/<path>#<field>" reference, e.g.
+// vault://kv/data/prod/servers/foo#password.
+type vaultRef struct {
+	mount string
+	path  string
+	field string
+}
+
+func parseVaultRef(ref string) (vaultRef, error) {
+	const prefix = "vault://"
+	if !strings.HasPrefix(ref, prefix) {
+		return vaultRef{}, fmt.Errorf("not a vault reference: %s", ref)
+	}
+
+	rest := strings.TrimPrefix(ref, prefix)
+	parts := strings.SplitN(rest, "#", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return vaultRef{}, fmt.Errorf("vault reference %q is missing a #field", ref)
+	}
+
+	segs := strings.SplitN(parts[0], "/", 2)
+	if len(segs) != 2 || segs[1] == "" {
+		return vaultRef{}, fmt.Errorf("vault reference %q is missing a mount/path", ref)
+	}
+
+	// Accept both "kv/prod/foo" and "kv/data/prod/foo" so users don't need to
+	// know whether the mount is KV v1 or v2 when writing the config.
+	path := strings.TrimPrefix(segs[1], "data/")
+
+	return vaultRef{mount: segs[0], path: path, field: parts[1]}, nil
+}
+
+// vaultResolver fetches and caches KV secrets for a single script invocation.
+type vaultResolver struct {
+	client      *vaultapi.Client
+	mountVers   map[string]int
+	secretCache map[string]map[string]interface{}
+}
+
+// vaultResolveError marks a failure as originating from vault connectivity
+// or secret resolution, as opposed to a general script-setup failure, so
+// callers can surface it under a "Vault error" banner instead of a generic
+// one.
+type vaultResolveError struct{ err error }
+
+func (e *vaultResolveError) Error() string { return e.err.Error() }
+func (e *vaultResolveError) Unwrap() error { return e.err }
+
+func newVaultResolver(addr, token string) (*vaultResolver, error) {
+	cfg := vaultapi.DefaultConfig()
+	if addr != "" {
+		cfg.Address = addr
+	}
+
+	client, err := vaultapi.NewClient(cfg)
+	if err != nil {
+		return nil, &vaultResolveError{fmt.Errorf("could not create vault client: %v", err)}
+	}
+	client.SetToken(token)
+
+	return &vaultResolver{
+		client:      client,
+		mountVers:   map[string]int{},
+		secretCache: map[string]map[string]interface{}{},
+	}, nil
+}
+
+// mountVersion auto-detects whether a mount is KV v1 or v2 via sys/mounts.
+func (v *vaultResolver) mountVersion(mount string) (int, error) {
+	if version, ok := v.mountVers[mount]; ok {
+		return version, nil
+	}
+
+	mounts, err := v.client.Sys().ListMounts()
+	if err != nil {
+		return 0, fmt.Errorf("could not list vault mounts: %v", err)
+	}
+
+	info, ok := mounts[mount+"/"]
+	if !ok {
+		return 0, fmt.Errorf("vault mount %q not found", mount)
+	}
+
+	version := 1
+	if info.Options != nil && info.Options["version"] == "2" {
+		version = 2
+	}
+
+	v.mountVers[mount] = version
+	return version, nil
+}
+
+// kvAPIPath builds the Logical().Read path for a mount/path pair given its
+// KV engine version: v1 reads "<mount>/<path>" directly, v2 nests secrets
+// under an extra "data/" segment.
+func kvAPIPath(mount, path string, version int) string {
+	if version == 2 {
+		return fmt.Sprintf("%s/data/%s", mount, path)
+	}
+	return fmt.Sprintf("%s/%s", mount, path)
+}
+
+func (v *vaultResolver) readSecret(ref vaultRef) (map[string]interface{}, error) {
+	cacheKey := ref.mount + "/" + ref.path
+	if data, ok := v.secretCache[cacheKey]; ok {
+		return data, nil
+	}
+
+	version, err := v.mountVersion(ref.mount)
+	if err != nil {
+		return nil, err
+	}
+
+	apiPath := kvAPIPath(ref.mount, ref.path, version)
+
+	secret, err := v.client.Logical().Read(apiPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %v", apiPath, err)
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("no secret found at %s", apiPath)
+	}
+
+	data := secret.Data
+	if version == 2 {
+		inner, ok := secret.Data["data"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected kv2 response shape at %s", apiPath)
+		}
+		data = inner
+	}
+
+	v.secretCache[cacheKey] = data
+	return data, nil
+}
+
+// resolveSecrets batch-fetches the vault:// references in secrets and
+// returns a plain envKey -> value map ready to be injected into an exec
+// environment.
+func (v *vaultResolver) resolveSecrets(secrets map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(secrets))
+
+	for envKey, ref := range secrets {
+		parsed, err := parseVaultRef(ref)
+		if err != nil {
+			return nil, &vaultResolveError{err}
+		}
+
+		data, err := v.readSecret(parsed)
+		if err != nil {
+			return nil, &vaultResolveError{err}
+		}
+
+		value, ok := data[parsed.field]
+		if !ok {
+			return nil, &vaultResolveError{fmt.Errorf("field %q not found at %s", parsed.field, ref)}
+		}
+
+		resolved[envKey] = fmt.Sprintf("%v", value)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import "testing"
+
+func TestParseVaultRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     string
+		want    vaultRef
+		wantErr bool
+	}{
+		{
+			name: "kv2 path with explicit data segment",
+			ref:  "vault://kv/data/prod/servers/foo#password",
+			want: vaultRef{mount: "kv", path: "prod/servers/foo", field: "password"},
+		},
+		{
+			name: "kv1-style path without a data segment",
+			ref:  "vault://kv/prod/servers/foo#password",
+			want: vaultRef{mount: "kv", path: "prod/servers/foo", field: "password"},
+		},
+		{
+			name:    "missing vault:// prefix",
+			ref:     "kv/prod/servers/foo#password",
+			wantErr: true,
+		},
+		{
+			name:    "missing #field",
+			ref:     "vault://kv/prod/servers/foo",
+			wantErr: true,
+		},
+		{
+			name:    "empty field",
+			ref:     "vault://kv/prod/servers/foo#",
+			wantErr: true,
+		},
+		{
+			name:    "missing mount/path",
+			ref:     "vault://kv#password",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVaultRef(tt.ref)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseVaultRef(%q) = %+v, want error", tt.ref, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseVaultRef(%q) returned unexpected error: %v", tt.ref, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseVaultRef(%q) = %+v, want %+v", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKVAPIPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		mount   string
+		path    string
+		version int
+		want    string
+	}{
+		{name: "v1 mount", mount: "kv", path: "prod/servers/foo", version: 1, want: "kv/prod/servers/foo"},
+		{name: "v2 mount nests under data/", mount: "kv", path: "prod/servers/foo", version: 2, want: "kv/data/prod/servers/foo"},
+		{name: "unknown version falls back to v1 shape", mount: "kv", path: "prod/servers/foo", version: 0, want: "kv/prod/servers/foo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kvAPIPath(tt.mount, tt.path, tt.version); got != tt.want {
+				t.Errorf("kvAPIPath(%q, %q, %d) = %q, want %q", tt.mount, tt.path, tt.version, got, tt.want)
+			}
+		})
+	}
+}